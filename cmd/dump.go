@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	core "k8s.io/api/core/v1"
+
+	"github.com/dodopizza/kubectl-shovel/internal/flags"
+	"github.com/dodopizza/kubectl-shovel/internal/globals"
+	"github.com/dodopizza/kubectl-shovel/internal/kubernetes"
+	"github.com/dodopizza/kubectl-shovel/internal/output"
+)
+
+// dumpOptions holds the parsed flag values for the dump command.
+type dumpOptions struct {
+	podName   string
+	selector  string
+	parallel  int
+	container string
+	image     string
+	mode      string
+
+	output string // sink URI: file://, s3://, gs://, http(s)://
+
+	runAsUser        int64
+	runAsGroup       int64
+	fsGroup          int64
+	seccompProfile   string
+	dropCapabilities []string
+	psa              string
+}
+
+// NewDumpCommand builds the `kubectl shovel dump` command.
+func NewDumpCommand() *cobra.Command {
+	opts := &dumpOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Dump diagnostics data from a container running inside a pod",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDump(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.podName, flags.PodName, "", "name of the target pod (mutually exclusive with --selector)")
+	cmd.Flags().StringVar(&opts.selector, flags.Selector, "", "label selector matching multiple target pods to dump concurrently")
+	cmd.Flags().IntVar(&opts.parallel, flags.Parallel, kubernetes.DefaultParallelism(), "number of pods to dump concurrently when --selector is used")
+	cmd.Flags().StringVar(&opts.container, flags.Container, "", "name of the target container (defaults to the pod's only/default container)")
+	cmd.Flags().StringVar(&opts.image, flags.Image, "", "image used for the dumper container")
+	cmd.Flags().StringVar(&opts.mode, flags.Mode, flags.ModeSidecar, "dumper injection mode: sidecar or ephemeral")
+	cmd.Flags().StringVar(&opts.output, flags.Output, "", "output sink URI (file://, s3://, gs://, http(s)://); a base path/prefix when --selector is used")
+
+	cmd.Flags().Int64Var(&opts.runAsUser, flags.RunAsUser, 0, "UID the dumper container runs as")
+	cmd.Flags().Int64Var(&opts.runAsGroup, flags.RunAsGroup, 0, "GID the dumper container runs as")
+	cmd.Flags().Int64Var(&opts.fsGroup, flags.FSGroup, 0, "supplemental group applied to the dumper pod's volumes")
+	cmd.Flags().StringVar(&opts.seccompProfile, flags.SeccompProfile, "", "seccomp profile type applied to the dumper container")
+	cmd.Flags().StringSliceVar(&opts.dropCapabilities, flags.DropCapabilities, nil, "capabilities to drop from the dumper container")
+	cmd.Flags().StringVar(&opts.psa, flags.PSA, "", "apply a Pod Security Admission preset to the dumper container (supported: restricted)")
+
+	_ = cmd.MarkFlagRequired(flags.Output)
+
+	return cmd
+}
+
+// securityOptions translates the raw --run-as-user/--run-as-group/
+// --fs-group/--seccomp-profile/--drop-capabilities/--psa flags into a
+// kubernetes.SecurityOptions, applying the requested PSA preset on top of
+// any explicit values.
+func (opts *dumpOptions) securityOptions() (kubernetes.SecurityOptions, error) {
+	security := kubernetes.SecurityOptions{
+		SeccompProfile:   opts.seccompProfile,
+		DropCapabilities: opts.dropCapabilities,
+	}
+
+	if opts.runAsUser != 0 {
+		security.RunAsUser = &opts.runAsUser
+	}
+	if opts.runAsGroup != 0 {
+		security.RunAsGroup = &opts.runAsGroup
+	}
+	if opts.fsGroup != 0 {
+		security.FSGroup = &opts.fsGroup
+	}
+
+	switch opts.psa {
+	case "":
+	case flags.PSARestricted:
+		security.Restricted = true
+	default:
+		return kubernetes.SecurityOptions{}, fmt.Errorf("unsupported --%s preset %q", flags.PSA, opts.psa)
+	}
+
+	return security, nil
+}
+
+// runDump validates the pod-name/selector combination and dispatches to a
+// single-pod or fan-out dump accordingly.
+func runDump(opts *dumpOptions) error {
+	if opts.podName == "" && opts.selector == "" {
+		return fmt.Errorf("one of --%s or --%s must be set", flags.PodName, flags.Selector)
+	}
+	if opts.podName != "" && opts.selector != "" {
+		return fmt.Errorf("--%s and --%s are mutually exclusive", flags.PodName, flags.Selector)
+	}
+
+	client, err := kubernetes.NewClientFromKubeconfig()
+	if err != nil {
+		return err
+	}
+
+	if opts.selector != "" {
+		return runDumpSelector(client, opts)
+	}
+
+	return dumpPod(client, opts, opts.podName, opts.output)
+}
+
+// runDumpSelector lists every pod matching --selector and dumps them
+// concurrently through a bounded worker pool, collecting every pod's error
+// (if any) into a single aggregate.
+func runDumpSelector(client *kubernetes.Client, opts *dumpOptions) error {
+	pods, err := client.ListPods(opts.selector)
+	if err != nil {
+		return fmt.Errorf("failed to list pods matching selector %q: %w", opts.selector, err)
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("no pods matched selector %q", opts.selector)
+	}
+
+	return kubernetes.RunParallel(pods, opts.parallel, func(pod core.Pod) error {
+		container, err := kubernetes.ResolveContainerName(&pod, opts.container)
+		if err != nil {
+			return err
+		}
+
+		podOutput, err := output.WithSuffix(opts.output, fmt.Sprintf("%s-%s.dump", pod.Name, container))
+		if err != nil {
+			return err
+		}
+		return dumpPod(client, opts, pod.Name, podOutput)
+	})
+}
+
+// dumpPod injects a dumper into a single target pod using the requested
+// mode; the dumper container streams its artifact directly to outputURI via
+// its own uploader binary, see internal/output and cmd/shovel-uploader.
+func dumpPod(client *kubernetes.Client, opts *dumpOptions, podName, outputURI string) error {
+	target, err := client.GetPod(podName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch target pod %q: %w", podName, err)
+	}
+
+	container, err := kubernetes.ResolveContainerName(target, opts.container)
+	if err != nil {
+		return err
+	}
+
+	security, err := opts.securityOptions()
+	if err != nil {
+		return err
+	}
+
+	dumperOpts := kubernetes.DumperOptions{
+		Image:     opts.image,
+		Container: container,
+		Output:    outputURI,
+		Security:  security,
+	}
+
+	switch opts.mode {
+	case flags.ModeEphemeral:
+		pod, err := client.CreateEphemeralDumper(target, dumperOpts)
+		if err != nil {
+			return err
+		}
+		return client.WaitEphemeralContainerCompleted(pod.Name, globals.DumperContainerName)
+	case flags.ModeSidecar, "":
+		pod, err := client.CreateSidecarPod(target, dumperOpts)
+		if err != nil {
+			return err
+		}
+		return client.WaitContainerCompleted(pod.Name, globals.DumperContainerName)
+	default:
+		return fmt.Errorf("unknown dumper mode %q", opts.mode)
+	}
+}
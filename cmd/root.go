@@ -0,0 +1,19 @@
+// Package cmd wires the plugin's cobra commands together.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewRootCommand builds the top-level `kubectl shovel` command.
+func NewRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "shovel",
+		Short: "Dump diagnostics from a running pod",
+	}
+
+	root.AddCommand(NewDumpCommand())
+	root.AddCommand(NewWatchCommand())
+
+	return root
+}
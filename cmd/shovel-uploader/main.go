@@ -0,0 +1,44 @@
+// Command shovel-uploader runs inside the dumper container and streams the
+// dump artifact from stdin to the sink named by globals.EnvOutputURI,
+// resolved through internal/output.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dodopizza/kubectl-shovel/internal/globals"
+	"github.com/dodopizza/kubectl-shovel/internal/output"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	uri := os.Getenv(globals.EnvOutputURI)
+	if uri == "" {
+		return fmt.Errorf("%s must be set", globals.EnvOutputURI)
+	}
+
+	sink, err := output.New(uri)
+	if err != nil {
+		return err
+	}
+
+	writer, err := sink.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open output sink %q: %w", sink.URL(), err)
+	}
+
+	if _, err := io.Copy(writer, os.Stdin); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("failed to stream dump to %q: %w", sink.URL(), err)
+	}
+
+	return writer.Close()
+}
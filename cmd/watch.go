@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dodopizza/kubectl-shovel/internal/flags"
+	"github.com/dodopizza/kubectl-shovel/internal/kubernetes"
+	"github.com/dodopizza/kubectl-shovel/internal/output"
+	"github.com/dodopizza/kubectl-shovel/internal/watch"
+
+	core "k8s.io/api/core/v1"
+)
+
+// watchOptions holds the parsed flag values for the watch command.
+type watchOptions struct {
+	selector string
+	on       string
+
+	container string
+	image     string
+	mode      string
+	output    string
+}
+
+// NewWatchCommand builds the `kubectl shovel watch` command.
+func NewWatchCommand() *cobra.Command {
+	opts := &watchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Continuously capture a dump from matching pods each time a trigger fires",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.selector, flags.Selector, "", "label selector matching the pods to watch")
+	cmd.Flags().StringVar(&opts.on, flags.On, "", "trigger to capture on: liveness-fail, cpu>N%for=DURATION, oom, or schedule=@every DURATION")
+	cmd.Flags().StringVar(&opts.container, flags.Container, "", "name of the target container (defaults to the pod's only/default container)")
+	cmd.Flags().StringVar(&opts.image, flags.Image, "", "image used for the dumper container")
+	cmd.Flags().StringVar(&opts.mode, flags.Mode, flags.ModeSidecar, "dumper injection mode: sidecar or ephemeral")
+	cmd.Flags().StringVar(&opts.output, flags.Output, "", "output sink URI base; each capture is written under its own pod-and-timestamp key")
+
+	_ = cmd.MarkFlagRequired(flags.Selector)
+	_ = cmd.MarkFlagRequired(flags.On)
+	_ = cmd.MarkFlagRequired(flags.Output)
+
+	return cmd
+}
+
+// runWatch parses --on and runs the watch controller until interrupted.
+func runWatch(opts *watchOptions) error {
+	trigger, err := watch.Parse(opts.on)
+	if err != nil {
+		return err
+	}
+
+	client, err := kubernetes.NewClientFromKubeconfig()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	controller := &watch.Controller{
+		Client:   client,
+		Selector: opts.selector,
+		Trigger:  trigger,
+		Capture:  captureFunc(client, opts),
+	}
+
+	return controller.Run(ctx)
+}
+
+// captureFunc builds the watch.Capture invoked each time the trigger fires:
+// it runs the usual single-pod dump flow, writing to a key derived from the
+// pod name and the capture time so repeated captures don't overwrite each
+// other.
+func captureFunc(client *kubernetes.Client, opts *watchOptions) watch.Capture {
+	return func(pod *core.Pod) error {
+		key := fmt.Sprintf("%s-%d.dump", pod.Name, time.Now().Unix())
+		podOutput, err := output.WithSuffix(opts.output, key)
+		if err != nil {
+			return err
+		}
+
+		dumpOpts := &dumpOptions{
+			container: opts.container,
+			image:     opts.image,
+			mode:      opts.mode,
+		}
+
+		return dumpPod(client, dumpOpts, pod.Name, podOutput)
+	}
+}
@@ -0,0 +1,41 @@
+// Package flags provides helpers for building and naming the CLI flags
+// exposed by the plugin's commands.
+package flags
+
+import "fmt"
+
+// Args is a small builder used to assemble a kubectl plugin invocation's
+// argument list, mostly useful for tests that need to shell out to the
+// compiled binary.
+type Args struct {
+	values []string
+}
+
+// NewArgs returns an empty Args builder.
+func NewArgs() *Args {
+	return &Args{values: []string{}}
+}
+
+// AppendRaw appends a bare value, e.g. a subcommand name, to the argument
+// list.
+func (a *Args) AppendRaw(value string) *Args {
+	a.values = append(a.values, value)
+	return a
+}
+
+// Append appends a `--key=value` flag to the argument list.
+func (a *Args) Append(key, value string) *Args {
+	a.values = append(a.values, fmt.Sprintf("--%s=%s", key, value))
+	return a
+}
+
+// AppendKey appends a bare `--key` boolean flag to the argument list.
+func (a *Args) AppendKey(key string) *Args {
+	a.values = append(a.values, fmt.Sprintf("--%s", key))
+	return a
+}
+
+// Get returns the assembled argument list.
+func (a *Args) Get() []string {
+	return a.values
+}
@@ -0,0 +1,34 @@
+package flags
+
+// Flag names shared between the cobra command definitions in cmd/ and the
+// tests that shell out to the compiled binary.
+const (
+	PodName   = "pod-name"
+	Selector  = "selector"
+	Parallel  = "parallel"
+	Container = "container"
+	Image     = "image"
+
+	Output = "output"
+	Mode   = "mode"
+
+	On = "on"
+
+	RunAsUser        = "run-as-user"
+	RunAsGroup       = "run-as-group"
+	FSGroup          = "fs-group"
+	SeccompProfile   = "seccomp-profile"
+	DropCapabilities = "drop-capabilities"
+	PSA              = "psa"
+)
+
+// Dumper injection modes accepted by the --mode flag.
+const (
+	ModeSidecar   = "sidecar"
+	ModeEphemeral = "ephemeral"
+)
+
+// PSARestricted is the only preset accepted by the --psa flag today: it
+// configures the dumper container to comply with the Pod Security Admission
+// "restricted" level.
+const PSARestricted = "restricted"
@@ -0,0 +1,24 @@
+// Package globals holds constants shared across the plugin's commands and
+// internal packages.
+package globals
+
+const (
+	// PluginName is the name kubectl uses to invoke this plugin and the
+	// prefix used for any on-disk state (temp dirs, lock files, etc).
+	PluginName = "kubectl-shovel"
+
+	// PathTmpFolder is the path, inside both the target and dumper
+	// containers, under which dump artifacts are written. The plugin does
+	// not collect them from there: the dumper streams its artifact to the
+	// output sink itself, see internal/output.
+	PathTmpFolder = "/tmp"
+
+	// DumperContainerName is the name given to the container that performs
+	// the actual dump, whether injected as a sidecar or as an ephemeral
+	// container on the target pod.
+	DumperContainerName = "dumper"
+
+	// EnvOutputURI is the environment variable the dumper container reads
+	// its output sink URI from, see internal/output.
+	EnvOutputURI = "SHOVEL_OUTPUT"
+)
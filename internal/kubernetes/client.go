@@ -0,0 +1,133 @@
+// Package kubernetes wraps the client-go clientset with the pod discovery,
+// injection and log-streaming helpers the plugin's commands need in order
+// to run a dumper against a target container.
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// waitPodTimeout bounds how long WaitPod will poll before giving up.
+const waitPodTimeout = 2 * time.Minute
+
+// defaultContainerAnnotation is the well-known annotation kubectl itself
+// honors to pick a default container on a pod with more than one, see
+// https://kubernetes.io/docs/reference/kubectl/generated/kubectl_commands/.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// Client is a thin wrapper around a client-go clientset, scoped to a single
+// namespace, used by every command to talk to the cluster.
+type Client struct {
+	Namespace string
+	Clientset kubernetes.Interface
+}
+
+// CoreV1 forwards to the underlying clientset's CoreV1 interface.
+func (c *Client) CoreV1() corev1.CoreV1Interface {
+	return c.Clientset.CoreV1()
+}
+
+// WaitPod polls until a pod matching labels is Running, returning it once
+// ready.
+func (c *Client) WaitPod(podLabels map[string]string) (*core.Pod, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), waitPodTimeout)
+	defer cancel()
+
+	selector := labels.SelectorFromSet(podLabels).String()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for pod with labels %q to become ready", selector)
+		default:
+		}
+
+		pods, err := c.CoreV1().Pods(c.Namespace).List(ctx, meta.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase == core.PodRunning {
+				return pod, nil
+			}
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// GetPod fetches a single pod by name.
+func (c *Client) GetPod(name string) (*core.Pod, error) {
+	return c.CoreV1().Pods(c.Namespace).Get(context.Background(), name, meta.GetOptions{})
+}
+
+// ListPods returns every pod matching the given label selector.
+func (c *Client) ListPods(selector string) ([]core.Pod, error) {
+	pods, err := c.CoreV1().Pods(c.Namespace).List(context.Background(), meta.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// ResolveContainerName returns requested if it is non-empty, otherwise
+// resolves the default target container for pod: its only container if it
+// has just one, or the container named by its defaultContainerAnnotation.
+// It errors if pod has more than one container and neither disambiguates
+// which one to target.
+func ResolveContainerName(pod *core.Pod, requested string) (string, error) {
+	if requested != "" {
+		return requested, nil
+	}
+
+	if len(pod.Spec.Containers) == 1 {
+		return pod.Spec.Containers[0].Name, nil
+	}
+
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		return name, nil
+	}
+
+	return "", fmt.Errorf("pod %q has multiple containers; specify --container or set the %q annotation", pod.Name, defaultContainerAnnotation)
+}
+
+// containerStatus finds name in statuses, which may be either a pod's
+// regular ContainerStatuses or its EphemeralContainerStatuses (both typed
+// []core.ContainerStatus).
+func containerStatus(statuses []core.ContainerStatus, name string) (core.ContainerStatus, bool) {
+	for _, status := range statuses {
+		if status.Name == name {
+			return status, true
+		}
+	}
+	return core.ContainerStatus{}, false
+}
+
+// streamLogs follows containerName's logs on podName to stdout, kubectl
+// attach-style, returning once the stream closes - normally because the
+// container has terminated.
+func streamLogs(c *Client, podName, containerName string) error {
+	stream, err := c.CoreV1().Pods(c.Namespace).GetLogs(podName, &core.PodLogOptions{
+		Container: containerName,
+		Follow:    true,
+	}).Stream(context.Background())
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(os.Stdout, stream)
+	return err
+}
@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateEphemeralDumper attaches the dumper as an ephemeral container on the
+// already-running target pod, using the EphemeralContainers subresource.
+// Unlike CreateSidecarPod, this never deletes or recreates the target pod:
+// the ephemeral container shares the target container's process namespace
+// via TargetContainerName, so no shared volume is needed to see its files
+// either.
+func (c *Client) CreateEphemeralDumper(target *core.Pod, opts DumperOptions) (*core.Pod, error) {
+	ctx := context.Background()
+
+	current, err := c.CoreV1().Pods(c.Namespace).Get(ctx, target.Name, meta.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pod %q before ephemeral injection: %w", target.Name, err)
+	}
+
+	container := dumperContainer(opts)
+	ephemeral := core.EphemeralContainer{
+		EphemeralContainerCommon: core.EphemeralContainerCommon{
+			Name:                     container.Name,
+			Image:                    container.Image,
+			ImagePullPolicy:          container.ImagePullPolicy,
+			TerminationMessagePolicy: container.TerminationMessagePolicy,
+			SecurityContext:          container.SecurityContext,
+			Env:                      container.Env,
+		},
+		TargetContainerName: opts.Container,
+	}
+	current.Spec.EphemeralContainers = append(current.Spec.EphemeralContainers, ephemeral)
+
+	updated, err := c.CoreV1().Pods(c.Namespace).UpdateEphemeralContainers(ctx, target.Name, current, meta.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add ephemeral dumper container to pod %q: %w", target.Name, err)
+	}
+
+	return updated, nil
+}
+
+// WaitEphemeralContainerCompleted streams the named ephemeral container's
+// logs on podName to stdout, kubectl attach-style, and blocks until it
+// terminates, returning an error if it exited with a non-zero code.
+func (c *Client) WaitEphemeralContainerCompleted(podName, containerName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), waitPodTimeout)
+	defer cancel()
+
+	streamed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for ephemeral container %q on pod %q to finish", containerName, podName)
+		default:
+		}
+
+		pod, err := c.CoreV1().Pods(c.Namespace).Get(ctx, podName, meta.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		status, ok := containerStatus(pod.Status.EphemeralContainerStatuses, containerName)
+		if !ok {
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if !streamed && status.State.Running != nil {
+			if err := streamLogs(c, podName, containerName); err != nil {
+				fmt.Println("shovel: failed to stream dumper logs:", err)
+			}
+			streamed = true
+		}
+
+		if terminated := status.State.Terminated; terminated != nil {
+			if terminated.ExitCode != 0 {
+				return fmt.Errorf("dumper ephemeral container %q on pod %q exited with code %d: %s", containerName, podName, terminated.ExitCode, terminated.Message)
+			}
+			return nil
+		}
+
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"runtime"
+	"sync"
+
+	core "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// DefaultParallelism is the default size of the worker pool used to dump
+// multiple pods concurrently, mirroring the number of available CPUs.
+func DefaultParallelism() int {
+	return runtime.NumCPU()
+}
+
+// RunParallel runs fn once per pod using a bounded pool of parallelism
+// workers, waits for every pod to finish, and returns an aggregate of every
+// error raised rather than failing fast.
+func RunParallel(pods []core.Pod, parallelism int, fn func(core.Pod) error) error {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism()
+	}
+
+	jobs := make(chan core.Pod)
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pod := range jobs {
+				if err := fn(pod); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, pod := range pods {
+		jobs <- pod
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dodopizza/kubectl-shovel/internal/globals"
+)
+
+// DumperOptions describes the dumper container that will be injected next
+// to (or into) the target pod.
+type DumperOptions struct {
+	Image     string
+	Container string // name of the target container to attach to
+	Output    string // output sink URI (file://, s3://, gs://, http(s)://)
+	Security  SecurityOptions
+}
+
+// dumperContainer builds the container spec run to perform the dump. It is
+// shared by both the sidecar and ephemeral injection paths. Output is
+// forwarded via globals.EnvOutputURI so the dumper image's own uploader
+// binary can stream the artifact directly to the sink, instead of the
+// plugin copying it out afterwards.
+func dumperContainer(opts DumperOptions) core.Container {
+	return core.Container{
+		Name:                     globals.DumperContainerName,
+		Image:                    opts.Image,
+		ImagePullPolicy:          core.PullIfNotPresent,
+		TerminationMessagePolicy: core.TerminationMessageFallbackToLogsOnError,
+		SecurityContext:          opts.Security.ContainerSecurityContext(),
+		Env: []core.EnvVar{
+			{Name: globals.EnvOutputURI, Value: opts.Output},
+		},
+	}
+}
+
+// CreateSidecarPod clones target, injects a dumper container that shares
+// the pod's process namespace, and (re-)creates it on the cluster. Because
+// containers cannot be added to a pod that is already running, this is a
+// delete-and-recreate operation; any container-local output that must
+// survive the recreation has to go through a shared volume mount, see
+// multiContainerPodWithSharedMount in the integration tests.
+func (c *Client) CreateSidecarPod(target *core.Pod, opts DumperOptions) (*core.Pod, error) {
+	clone := target.DeepCopy()
+	clone.ResourceVersion = ""
+	clone.UID = ""
+	clone.Status = core.PodStatus{}
+	clone.Spec.ShareProcessNamespace = boolPtr(true)
+	clone.Spec.SecurityContext = opts.Security.ApplyPodSecurityContext(clone.Spec.SecurityContext)
+	clone.Spec.Containers = append(clone.Spec.Containers, dumperContainer(opts))
+
+	ctx := context.Background()
+	if err := c.CoreV1().Pods(c.Namespace).Delete(ctx, target.Name, meta.DeleteOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to delete pod %q before sidecar injection: %w", target.Name, err)
+	}
+
+	if _, err := c.WaitPodDeleted(target.Name); err != nil {
+		return nil, err
+	}
+
+	return c.CoreV1().Pods(c.Namespace).Create(ctx, clone, meta.CreateOptions{})
+}
+
+// WaitPodDeleted polls until a pod by name no longer exists, or the timeout
+// elapses.
+func (c *Client) WaitPodDeleted(name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), waitPodTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("timed out waiting for pod %q to be deleted", name)
+		default:
+		}
+
+		_, err := c.CoreV1().Pods(c.Namespace).Get(ctx, name, meta.GetOptions{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// WaitContainerCompleted streams containerName's logs on podName to stdout,
+// kubectl attach-style, and blocks until the container terminates,
+// returning an error if it exited with a non-zero code. Used to wait out
+// the dumper container so callers only report success once the dump (and
+// its upload to the output sink) has actually finished.
+func (c *Client) WaitContainerCompleted(podName, containerName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), waitPodTimeout)
+	defer cancel()
+
+	streamed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for container %q on pod %q to finish", containerName, podName)
+		default:
+		}
+
+		pod, err := c.CoreV1().Pods(c.Namespace).Get(ctx, podName, meta.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		status, ok := containerStatus(pod.Status.ContainerStatuses, containerName)
+		if !ok {
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if !streamed && status.State.Running != nil {
+			if err := streamLogs(c, podName, containerName); err != nil {
+				fmt.Println("shovel: failed to stream dumper logs:", err)
+			}
+			streamed = true
+		}
+
+		if terminated := status.State.Terminated; terminated != nil {
+			if terminated.ExitCode != 0 {
+				return fmt.Errorf("dumper container %q on pod %q exited with code %d: %s", containerName, podName, terminated.ExitCode, terminated.Message)
+			}
+			return nil
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
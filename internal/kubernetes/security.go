@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	core "k8s.io/api/core/v1"
+)
+
+// SecurityOptions captures the dumper's security posture, populated from
+// the dump command's --run-as-user/--run-as-group/--fs-group/
+// --seccomp-profile/--drop-capabilities/--psa flags.
+type SecurityOptions struct {
+	RunAsUser        *int64
+	RunAsGroup       *int64
+	FSGroup          *int64
+	SeccompProfile   string
+	DropCapabilities []string
+
+	// Restricted, when set, applies the Pod Security Admission "restricted"
+	// preset on top of (and overriding) the individual fields above.
+	Restricted bool
+}
+
+// ContainerSecurityContext builds the dumper container's SecurityContext
+// from opts.
+func (opts SecurityOptions) ContainerSecurityContext() *core.SecurityContext {
+	sc := &core.SecurityContext{
+		RunAsUser:  opts.RunAsUser,
+		RunAsGroup: opts.RunAsGroup,
+	}
+
+	if len(opts.DropCapabilities) > 0 {
+		sc.Capabilities = &core.Capabilities{Drop: toCapabilities(opts.DropCapabilities)}
+	}
+
+	if opts.SeccompProfile != "" {
+		sc.SeccompProfile = &core.SeccompProfile{Type: core.SeccompProfileType(opts.SeccompProfile)}
+	}
+
+	if opts.Restricted {
+		sc.RunAsNonRoot = boolPtr(true)
+		sc.AllowPrivilegeEscalation = boolPtr(false)
+		sc.Capabilities = &core.Capabilities{Drop: []core.Capability{"ALL"}}
+		sc.SeccompProfile = &core.SeccompProfile{Type: core.SeccompProfileTypeRuntimeDefault}
+	}
+
+	return sc
+}
+
+// ApplyPodSecurityContext merges opts into a copy of existing (the target
+// pod's current pod-level SecurityContext, which may be nil), only
+// overriding fields the user actually set via flags. This preserves
+// settings the original workload depends on, e.g. FSGroup,
+// SupplementalGroups or SELinuxOptions, instead of replacing the whole
+// PodSecurityContext with one built solely from --run-as-*/--psa.
+func (opts SecurityOptions) ApplyPodSecurityContext(existing *core.PodSecurityContext) *core.PodSecurityContext {
+	psc := existing.DeepCopy()
+	if psc == nil {
+		psc = &core.PodSecurityContext{}
+	}
+
+	if opts.FSGroup != nil {
+		psc.FSGroup = opts.FSGroup
+	}
+
+	if opts.Restricted {
+		psc.RunAsNonRoot = boolPtr(true)
+		psc.SeccompProfile = &core.SeccompProfile{Type: core.SeccompProfileTypeRuntimeDefault}
+	}
+
+	return psc
+}
+
+func toCapabilities(names []string) []core.Capability {
+	caps := make([]core.Capability, len(names))
+	for i, name := range names {
+		caps[i] = core.Capability(name)
+	}
+	return caps
+}
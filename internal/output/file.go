@@ -0,0 +1,38 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+func init() {
+	Register("file", newFileSink)
+}
+
+// fileSink writes a dump artifact to the local (or node-hostPath-mounted)
+// filesystem.
+type fileSink struct {
+	path string
+}
+
+func newFileSink(uri *url.URL) (Sink, error) {
+	path := uri.Path
+	if path == "" {
+		return nil, fmt.Errorf("file:// output uri must have a path, got %q", uri.String())
+	}
+	return &fileSink{path: path}, nil
+}
+
+func (s *fileSink) Open() (io.WriteCloser, error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create output directory for %q: %w", s.path, err)
+	}
+	return os.Create(s.path)
+}
+
+func (s *fileSink) URL() string {
+	return "file://" + s.path
+}
@@ -0,0 +1,46 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"cloud.google.com/go/storage"
+)
+
+func init() {
+	Register("gs", newGCSSink)
+}
+
+// gcsSink uploads a dump artifact to a Google Cloud Storage bucket.
+type gcsSink struct {
+	uri    string
+	bucket string
+	object string
+}
+
+func newGCSSink(uri *url.URL) (Sink, error) {
+	bucket := uri.Host
+	object := strings.TrimPrefix(uri.Path, "/")
+	if bucket == "" || object == "" {
+		return nil, fmt.Errorf("gs:// output uri must be of the form gs://bucket/object, got %q", uri.String())
+	}
+
+	return &gcsSink{uri: uri.String(), bucket: bucket, object: object}, nil
+}
+
+func (s *gcsSink) Open() (io.WriteCloser, error) {
+	client, err := storage.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+
+	writer := client.Bucket(s.bucket).Object(s.object).NewWriter(context.Background())
+	return writer, nil
+}
+
+func (s *gcsSink) URL() string {
+	return s.uri
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+func init() {
+	Register("http", newHTTPSink)
+	Register("https", newHTTPSink)
+}
+
+// httpSink streams a dump artifact to a remote endpoint via HTTP PUT.
+type httpSink struct {
+	uri string
+}
+
+func newHTTPSink(uri *url.URL) (Sink, error) {
+	return &httpSink{uri: uri.String()}, nil
+}
+
+func (s *httpSink) Open() (io.WriteCloser, error) {
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+
+	go func() {
+		req, err := http.NewRequest(http.MethodPut, s.uri, reader)
+		if err != nil {
+			done <- err
+			return
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			done <- err
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			done <- fmt.Errorf("PUT %s: unexpected status %s", s.uri, resp.Status)
+			return
+		}
+
+		done <- nil
+	}()
+
+	return &pipeWriteCloser{writer: writer, done: done}, nil
+}
+
+func (s *httpSink) URL() string {
+	return s.uri
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func init() {
+	Register("s3", newS3Sink)
+}
+
+// s3Sink uploads a dump artifact to an S3-compatible bucket.
+type s3Sink struct {
+	uri    string
+	bucket string
+	key    string
+}
+
+func newS3Sink(uri *url.URL) (Sink, error) {
+	bucket := uri.Host
+	key := strings.TrimPrefix(uri.Path, "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("s3:// output uri must be of the form s3://bucket/key, got %q", uri.String())
+	}
+
+	return &s3Sink{uri: uri.String(), bucket: bucket, key: key}, nil
+}
+
+func (s *s3Sink) Open() (io.WriteCloser, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	uploader := manager.NewUploader(s3.NewFromConfig(cfg))
+
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := uploader.Upload(context.Background(), &s3.PutObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(s.key),
+			Body:   reader,
+		})
+		done <- err
+	}()
+
+	return &pipeWriteCloser{writer: writer, done: done}, nil
+}
+
+func (s *s3Sink) URL() string {
+	return s.uri
+}
@@ -0,0 +1,81 @@
+// Package output provides a pluggable destination for dump artifacts,
+// addressed by URI (file://, s3://, gs://, http(s)://) rather than a fixed
+// set of "store on host" / "download" flags.
+package output
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+)
+
+// Sink is a single write destination for a dump artifact.
+type Sink interface {
+	// Open returns a writer that streams the artifact to the sink. The
+	// caller is responsible for closing it once the dump is complete.
+	Open() (io.WriteCloser, error)
+
+	// URL returns the sink's URI, as reported to the user once the dump
+	// completes.
+	URL() string
+}
+
+// Factory constructs a Sink from a parsed URI of the scheme it was
+// registered under.
+type Factory func(uri *url.URL) (Sink, error)
+
+var registry = map[string]Factory{}
+
+// Register associates a URI scheme with a Sink Factory. Called from the
+// init() of each sink implementation.
+func Register(scheme string, factory Factory) {
+	registry[scheme] = factory
+}
+
+// New parses uri and builds the Sink registered for its scheme.
+func New(uri string) (Sink, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse output uri %q: %w", uri, err)
+	}
+
+	factory, ok := registry[parsed.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported output scheme %q (supported: file, s3, gs, http, https)", parsed.Scheme)
+	}
+
+	return factory(parsed)
+}
+
+// WithSuffix returns uri with name appended to its path, used to derive a
+// per-pod artifact location from a single --output base URI when fanning
+// out to multiple pods.
+func WithSuffix(uri, name string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse output uri %q: %w", uri, err)
+	}
+
+	parsed.Path = path.Join(parsed.Path, name)
+	return parsed.String(), nil
+}
+
+// pipeWriteCloser adapts an io.PipeWriter fed by the dumper to a background
+// upload goroutine that reads from the matching io.PipeReader, surfacing the
+// upload's result (if any) on Close.
+type pipeWriteCloser struct {
+	writer *io.PipeWriter
+	done   chan error
+}
+
+func (p *pipeWriteCloser) Write(b []byte) (int, error) {
+	return p.writer.Write(b)
+}
+
+func (p *pipeWriteCloser) Close() error {
+	if err := p.writer.Close(); err != nil {
+		return err
+	}
+	return <-p.done
+}
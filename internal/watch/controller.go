@@ -0,0 +1,172 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/dodopizza/kubectl-shovel/internal/kubernetes"
+)
+
+// Capture is invoked once per trigger firing, with the pod that triggered
+// it.
+type Capture func(pod *core.Pod) error
+
+// Controller keeps running against Selector until its context is cancelled,
+// invoking Capture each time Trigger fires.
+type Controller struct {
+	Client   *kubernetes.Client
+	Selector string
+	Trigger  *Trigger
+	Capture  Capture
+
+	// fired tracks, per pod name, the incident key last captured by
+	// watchPods, so a condition that stays true across many informer
+	// resyncs (e.g. LastTerminationState never clearing, or repeated
+	// Update events inside the liveness-fail window) only re-fires
+	// Capture when a genuinely new incident is observed.
+	fired map[string]string
+}
+
+// Run blocks until ctx is cancelled or an unrecoverable error occurs.
+func (c *Controller) Run(ctx context.Context) error {
+	switch c.Trigger.Kind {
+	case KindLivenessFail:
+		return c.watchPods(ctx, c.firedOnLivenessFail)
+	case KindOOM:
+		return c.watchPods(ctx, c.firedOnOOM)
+	case KindCPU:
+		return c.watchCPU(ctx)
+	case KindSchedule:
+		return c.watchSchedule(ctx)
+	default:
+		return fmt.Errorf("unsupported trigger kind %q", c.Trigger.Kind)
+	}
+}
+
+// watchPods runs an informer over pods matching Selector, firing Capture
+// whenever check reports a match on an add/update event whose incident key
+// differs from the last one captured for that pod. check returns the
+// incident key identifying the specific occurrence of the condition (e.g. a
+// termination timestamp) alongside whether the condition currently holds.
+func (c *Controller) watchPods(ctx context.Context, check func(pod *core.Pod) (incident string, ok bool)) error {
+	if c.fired == nil {
+		c.fired = map[string]string{}
+	}
+
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options meta.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = c.Selector
+			return c.Client.CoreV1().Pods(c.Client.Namespace).List(ctx, options)
+		},
+		WatchFunc: func(options meta.ListOptions) (apiwatch.Interface, error) {
+			options.LabelSelector = c.Selector
+			return c.Client.CoreV1().Pods(c.Client.Namespace).Watch(ctx, options)
+		},
+	}
+
+	handle := func(obj interface{}) {
+		pod, ok := obj.(*core.Pod)
+		if !ok {
+			return
+		}
+
+		incident, matched := check(pod)
+		if !matched || c.fired[pod.Name] == incident {
+			return
+		}
+		c.fired[pod.Name] = incident
+
+		if err := c.Capture(pod); err != nil {
+			fmt.Println("shovel watch: capture failed:", err)
+		}
+	}
+
+	_, informer := cache.NewInformer(listWatch, &core.Pod{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    handle,
+		UpdateFunc: func(_, newObj interface{}) { handle(newObj) },
+	})
+
+	informer.Run(ctx.Done())
+	return nil
+}
+
+// firedOnOOM reports whether any container in pod was last terminated with
+// reason OOMKilled, keying the incident on the container name, restart
+// count and termination timestamp so the same OOM event is not captured
+// again on every later status resync.
+func (c *Controller) firedOnOOM(pod *core.Pod) (string, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		terminated := status.LastTerminationState.Terminated
+		if terminated != nil && terminated.Reason == "OOMKilled" {
+			incident := fmt.Sprintf("%s/%d/%s", status.Name, status.RestartCount, terminated.FinishedAt.Time)
+			return incident, true
+		}
+	}
+	return "", false
+}
+
+// firedOnLivenessFail reports whether pod has a recent Warning "Unhealthy"
+// event recorded against it, keying the incident on that event's UID.
+// Kubelet aggregates repeated probe failures into a single Event object and
+// keeps advancing its LastTimestamp, while its UID (like its FirstTimestamp)
+// stays fixed for the life of that incident, so this doesn't re-fire on
+// every later Update inside the same 30s window the way keying on
+// LastTimestamp would.
+func (c *Controller) firedOnLivenessFail(pod *core.Pod) (string, bool) {
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("involvedObject.name", pod.Name),
+		fields.OneTermEqualSelector("reason", "Unhealthy"),
+	)
+
+	events, err := c.Client.CoreV1().Events(c.Client.Namespace).List(context.Background(), meta.ListOptions{
+		FieldSelector: selector.String(),
+	})
+	if err != nil {
+		return "", false
+	}
+
+	var latest *core.Event
+	for i := range events.Items {
+		event := &events.Items[i]
+		if time.Since(event.LastTimestamp.Time) < 30*time.Second &&
+			(latest == nil || event.LastTimestamp.After(latest.LastTimestamp.Time)) {
+			latest = event
+		}
+	}
+	if latest == nil {
+		return "", false
+	}
+	return string(latest.UID), true
+}
+
+// watchSchedule fires Capture against every pod matching Selector on a
+// fixed interval.
+func (c *Controller) watchSchedule(ctx context.Context) error {
+	ticker := time.NewTicker(c.Trigger.Every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			pods, err := c.Client.ListPods(c.Selector)
+			if err != nil {
+				return err
+			}
+			for i := range pods {
+				if err := c.Capture(&pods[i]); err != nil {
+					fmt.Println("shovel watch: capture failed:", err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package watch
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	core "k8s.io/api/core/v1"
+)
+
+// cpuUsagePercent scrapes the kubelet's /metrics/resource endpoint on the
+// node hosting pod for its containers' CPU usage. The scraped series is a
+// cumulative counter, so this takes two samples a second apart and reports
+// the rate between them as a percentage of a single core.
+func (c *Controller) cpuUsagePercent(ctx context.Context, pod *core.Pod) (float64, error) {
+	first, err := scrapeCPUSeconds(ctx, pod)
+	if err != nil {
+		return 0, err
+	}
+
+	time.Sleep(time.Second)
+
+	second, err := scrapeCPUSeconds(ctx, pod)
+	if err != nil {
+		return 0, err
+	}
+
+	return (second - first) * 100, nil
+}
+
+func scrapeCPUSeconds(ctx context.Context, pod *core.Pod) (float64, error) {
+	url := fmt.Sprintf("https://%s:10250/metrics/resource", pod.Status.HostIP)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var total float64
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "container_cpu_usage_seconds_total") || !strings.Contains(line, pod.Name) {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		value, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+		if err == nil {
+			total += value
+		}
+	}
+
+	return total, scanner.Err()
+}
+
+// watchCPU polls cpuUsagePercent for every pod matching Selector, firing
+// Capture once usage has stayed above Trigger.Threshold for Trigger.For.
+func (c *Controller) watchCPU(ctx context.Context) error {
+	const pollInterval = 5 * time.Second
+
+	above := map[string]time.Time{} // pod name -> when it first crossed Threshold
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			pods, err := c.Client.ListPods(c.Selector)
+			if err != nil {
+				return err
+			}
+
+			for i := range pods {
+				pod := &pods[i]
+
+				usage, err := c.cpuUsagePercent(ctx, pod)
+				if err != nil {
+					continue
+				}
+
+				if usage < c.Trigger.Threshold {
+					delete(above, pod.Name)
+					continue
+				}
+
+				since, seen := above[pod.Name]
+				if !seen {
+					above[pod.Name] = time.Now()
+					continue
+				}
+
+				if time.Since(since) >= c.Trigger.For {
+					if err := c.Capture(pod); err != nil {
+						fmt.Println("shovel watch: capture failed:", err)
+					}
+					delete(above, pod.Name)
+				}
+			}
+		}
+	}
+}
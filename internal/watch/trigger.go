@@ -0,0 +1,89 @@
+// Package watch implements the `shovel watch` controller: it keeps running
+// against a pod selector and captures a dump each time a configured trigger
+// fires.
+package watch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind identifies which condition a Trigger watches for.
+type Kind string
+
+const (
+	// KindLivenessFail fires when a container's liveness probe starts
+	// failing (surfaced as a Warning "Unhealthy" event).
+	KindLivenessFail Kind = "liveness-fail"
+	// KindCPU fires when a container's CPU usage stays above Threshold for
+	// at least For.
+	KindCPU Kind = "cpu"
+	// KindOOM fires when a container is restarted with reason OOMKilled.
+	KindOOM Kind = "oom"
+	// KindSchedule fires on a fixed interval, e.g. "@every 1h".
+	KindSchedule Kind = "schedule"
+)
+
+// Trigger is a parsed --on flag value.
+type Trigger struct {
+	Kind Kind
+
+	Threshold float64       // KindCPU: percentage, e.g. 90 for "90%"
+	For       time.Duration // KindCPU: how long Threshold must hold
+	Every     time.Duration // KindSchedule: interval between captures
+}
+
+// Parse parses the grammar accepted by --on:
+//
+//	liveness-fail
+//	cpu>90%for=30s
+//	oom
+//	schedule=@every 1h
+func Parse(raw string) (*Trigger, error) {
+	switch {
+	case raw == string(KindLivenessFail):
+		return &Trigger{Kind: KindLivenessFail}, nil
+
+	case raw == string(KindOOM):
+		return &Trigger{Kind: KindOOM}, nil
+
+	case strings.HasPrefix(raw, "cpu>"):
+		return parseCPUTrigger(raw)
+
+	case strings.HasPrefix(raw, "schedule=@every "):
+		interval := strings.TrimPrefix(raw, "schedule=@every ")
+		every, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --on schedule interval %q: %w", interval, err)
+		}
+		return &Trigger{Kind: KindSchedule, Every: every}, nil
+
+	default:
+		return nil, fmt.Errorf("unrecognized --on trigger %q", raw)
+	}
+}
+
+// parseCPUTrigger parses "cpu>90%for=30s".
+func parseCPUTrigger(raw string) (*Trigger, error) {
+	body := strings.TrimPrefix(raw, "cpu>")
+
+	parts := strings.SplitN(body, "for=", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("cpu trigger %q must be of the form cpu>N%%for=DURATION", raw)
+	}
+
+	percent := strings.TrimSuffix(strings.TrimSpace(parts[0]), "%")
+	threshold, err := strconv.ParseFloat(percent, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cpu threshold %q: %w", parts[0], err)
+	}
+
+	for_, err := time.ParseDuration(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cpu trigger duration %q: %w", parts[1], err)
+	}
+
+	return &Trigger{Kind: KindCPU, Threshold: threshold, For: for_}, nil
+}
@@ -0,0 +1,50 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pluginBinary is the compiled plugin binary exercised by these tests; it
+// must be on PATH or overridden via KUBECTL_SHOVEL_BIN.
+func pluginBinary() string {
+	if bin := os.Getenv("KUBECTL_SHOVEL_BIN"); bin != "" {
+		return bin
+	}
+	return "kubectl-shovel"
+}
+
+func runTestCase(t *testing.T, tc *TestCase) {
+	t.Helper()
+
+	teardownCase := testCaseSetup(t, tc, "dump")
+	defer teardownCase()
+
+	cmd := exec.Command(pluginBinary(), tc.FormatArgs("dump")...)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "dump command failed: %s", string(output))
+
+	if path, ok := strings.CutPrefix(tc.output, "file://"); ok {
+		_, err := os.Stat(path)
+		require.NoError(t, err, "expected output file at %s", path)
+	}
+}
+
+func TestDump(t *testing.T) {
+	teardown := testSetup(t, "dump")
+	defer teardown()
+
+	for _, tc := range cases() {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			runTestCase(t, tc)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"testing"
+)
+
+// TestDumpEphemeralMode exercises the --mode=ephemeral dumper injection:
+// unlike the sidecar mode it attaches directly to the already-running
+// target pod via the EphemeralContainers subresource, so none of the
+// multiContainerPodWithSharedMount gymnastics are needed to retrieve the
+// dump.
+func TestDumpEphemeralMode(t *testing.T) {
+	teardown := testSetup(t, "dump")
+	defer teardown()
+
+	tc := NewTestCase("Ephemeral container mode").
+		WithMode("ephemeral")
+
+	runTestCase(t, tc)
+}
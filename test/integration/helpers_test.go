@@ -41,15 +41,16 @@ var (
 )
 
 type TestCase struct {
-	name       string
-	args       []string
-	pod        *core.Pod
-	output     string
-	hostOutput bool
+	name         string
+	args         []string
+	pod          *core.Pod
+	output       string
+	customOutput bool
+	mode         string
 }
 
 func NewTestCase(name string) *TestCase {
-	return &TestCase{name: name, args: []string{}, pod: singleContainerPod(), hostOutput: true}
+	return &TestCase{name: name, args: []string{}, pod: singleContainerPod()}
 }
 
 func (tc *TestCase) WithPod(pod *core.Pod) *TestCase {
@@ -57,8 +58,17 @@ func (tc *TestCase) WithPod(pod *core.Pod) *TestCase {
 	return tc
 }
 
-func (tc *TestCase) DownloadOutput() *TestCase {
-	tc.hostOutput = false
+func (tc *TestCase) WithMode(mode string) *TestCase {
+	tc.mode = mode
+	return tc
+}
+
+// WithOutput pins the test case to a specific output sink URI (e.g.
+// "s3://bucket/key") instead of the local file:// path testCaseSetup
+// allocates by default.
+func (tc *TestCase) WithOutput(uri string) *TestCase {
+	tc.output = uri
+	tc.customOutput = true
 	return tc
 }
 
@@ -73,13 +83,12 @@ func (tc *TestCase) WithArgs(args ...string) *TestCase {
 func (tc *TestCase) FormatArgs(command string) []string {
 	args := flags.NewArgs().
 		AppendRaw(command).
-		Append("pod-name", tc.pod.Name).
-		Append("image", DumperImage)
+		Append(flags.PodName, tc.pod.Name).
+		Append(flags.Image, DumperImage).
+		Append(flags.Output, tc.output)
 
-	if tc.hostOutput {
-		args.AppendKey("store-output-on-host")
-	} else {
-		args.Append("output", tc.output)
+	if tc.mode != "" {
+		args.Append(flags.Mode, tc.mode)
 	}
 
 	for key := 0; key < len(tc.args); key += 2 {
@@ -143,10 +152,10 @@ func testCaseSetup(t *testing.T, tc *TestCase, command string) func() {
 	_, err = k.WaitPod(tc.pod.ObjectMeta.Labels)
 	require.NoError(t, err)
 
-	if !tc.hostOutput {
+	if !tc.customOutput {
 		parent := filepath.Join(os.TempDir(), globals.PluginName, command)
 		dir, _ := ioutil.TempDir(parent, "*")
-		tc.output = filepath.Join(dir, "output")
+		tc.output = "file://" + filepath.Join(dir, "output")
 		t.Logf("Output for test case will be stored at: %s\n", tc.output)
 	}
 
@@ -174,7 +183,24 @@ func generateRandomPodMeta() meta.ObjectMeta {
 	}
 }
 
-func targetContainer() core.Container {
+func podWithLabel(label string) *core.Pod {
+	name := fmt.Sprintf("%s-%s", targetPodNamePrefix, uuid.NewString())
+
+	return &core.Pod{
+		ObjectMeta: meta.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"app": label},
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{targetContainer(nil)},
+		},
+	}
+}
+
+// targetContainer builds the sample app container. securityContext is
+// optional (pass nil for the zero value) and lets PSA-restricted test cases
+// build a target container that satisfies the namespace's admission policy.
+func targetContainer(securityContext *core.SecurityContext) core.Container {
 	return core.Container{
 		Name:            targetContainerName,
 		Image:           TargetContainerImage,
@@ -202,13 +228,18 @@ func targetContainer() core.Container {
 			FailureThreshold:    5,
 		},
 		TerminationMessagePolicy: core.TerminationMessageFallbackToLogsOnError,
+		SecurityContext:          securityContext,
 	}
 }
 
-func sidecarContainer() core.Container {
+// sidecarContainer builds the companion pause container used by the
+// multi-container test pods. securityContext is optional, see
+// targetContainer.
+func sidecarContainer(securityContext *core.SecurityContext) core.Container {
 	return core.Container{
-		Name:  sidecarContainerName,
-		Image: SidecarContainerImage,
+		Name:            sidecarContainerName,
+		Image:           SidecarContainerImage,
+		SecurityContext: securityContext,
 	}
 }
 
@@ -216,7 +247,7 @@ func singleContainerPod() *core.Pod {
 	return &core.Pod{
 		ObjectMeta: generateRandomPodMeta(),
 		Spec: core.PodSpec{
-			Containers: []core.Container{targetContainer()},
+			Containers: []core.Container{targetContainer(nil)},
 		},
 	}
 }
@@ -225,7 +256,7 @@ func multiContainerPod() *core.Pod {
 	return &core.Pod{
 		ObjectMeta: generateRandomPodMeta(),
 		Spec: core.PodSpec{
-			Containers: []core.Container{targetContainer(), sidecarContainer()},
+			Containers: []core.Container{targetContainer(nil), sidecarContainer(nil)},
 		},
 	}
 }
@@ -238,7 +269,7 @@ func multiContainerPodWithDefaultContainer() *core.Pod {
 	return &core.Pod{
 		ObjectMeta: objectMeta,
 		Spec: core.PodSpec{
-			Containers: []core.Container{targetContainer(), sidecarContainer()},
+			Containers: []core.Container{targetContainer(nil), sidecarContainer(nil)},
 		},
 	}
 }
@@ -259,10 +290,10 @@ func multiContainerPodWithSharedMount() *core.Pod {
 		},
 	}
 
-	sidecar := sidecarContainer()
+	sidecar := sidecarContainer(nil)
 	sidecar.VolumeMounts = mounts
 
-	target := targetContainer()
+	target := targetContainer(nil)
 	target.VolumeMounts = mounts
 
 	return &core.Pod{
@@ -274,22 +305,45 @@ func multiContainerPodWithSharedMount() *core.Pod {
 	}
 }
 
+// restrictedSecurityContext satisfies the Pod Security Admission
+// "restricted" level, mirroring the --psa=restricted preset.
+func restrictedSecurityContext() *core.SecurityContext {
+	return &core.SecurityContext{
+		RunAsNonRoot:             boolPtr(true),
+		AllowPrivilegeEscalation: boolPtr(false),
+		Capabilities:             &core.Capabilities{Drop: []core.Capability{"ALL"}},
+		SeccompProfile:           &core.SeccompProfile{Type: core.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+// podInRestrictedNamespace builds a target pod whose own container already
+// satisfies the "restricted" admission level, so the only thing left to
+// verify is that the injected dumper container does too.
+func podInRestrictedNamespace() *core.Pod {
+	return &core.Pod{
+		ObjectMeta: generateRandomPodMeta(),
+		Spec: core.PodSpec{
+			Containers:      []core.Container{targetContainer(restrictedSecurityContext())},
+			SecurityContext: &core.PodSecurityContext{RunAsNonRoot: boolPtr(true)},
+		},
+	}
+}
+
 func cases(additional ...*TestCase) []*TestCase {
 	basic := []*TestCase{
-		NewTestCase("Basic test with output on host"),
-		NewTestCase("Basic test with downloading output").
-			DownloadOutput(),
+		NewTestCase("Basic test"),
 		NewTestCase("MultiContainer pod").
 			WithPod(multiContainerPod()).
-			WithArgs("container", targetContainerName).
-			DownloadOutput(),
+			WithArgs("container", targetContainerName),
 		NewTestCase("MultiContainer pod with default-container annotation").
-			WithPod(multiContainerPodWithDefaultContainer()).
-			DownloadOutput(),
+			WithPod(multiContainerPodWithDefaultContainer()),
 		NewTestCase("MultiContainer pod with shared mount").
 			WithPod(multiContainerPodWithSharedMount()).
-			WithArgs("container", targetContainerName).
-			DownloadOutput(),
+			WithArgs("container", targetContainerName),
 	}
 
 	return append(basic, additional...)
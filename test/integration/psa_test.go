@@ -0,0 +1,57 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+// restrictedLabel is the Pod Security Admission label applied to the test
+// namespace for the duration of TestDumpPSARestricted.
+const restrictedLabel = "pod-security.kubernetes.io/enforce"
+
+// TestDumpPSARestricted labels the test namespace as PSA "restricted" and
+// asserts --psa=restricted lets the dumper still get injected.
+func TestDumpPSARestricted(t *testing.T) {
+	teardown := testSetup(t, "dump")
+	defer teardown()
+
+	k := newTestKubeClient()
+	ctx := context.Background()
+
+	ns, err := k.CoreV1().Namespaces().Get(ctx, namespace, meta.GetOptions{})
+	require.NoError(t, err)
+
+	original := ns.Labels[restrictedLabel]
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[restrictedLabel] = "restricted"
+	_, err = k.CoreV1().Namespaces().Update(ctx, ns, meta.UpdateOptions{})
+	require.NoError(t, err)
+
+	defer func() {
+		ns, err := k.CoreV1().Namespaces().Get(ctx, namespace, meta.GetOptions{})
+		if err != nil {
+			return
+		}
+		if original == "" {
+			delete(ns.Labels, restrictedLabel)
+		} else {
+			ns.Labels[restrictedLabel] = original
+		}
+		_, _ = k.CoreV1().Namespaces().Update(ctx, ns, meta.UpdateOptions{})
+	}()
+
+	tc := NewTestCase("Dumper under PSA restricted namespace").
+		WithPod(podInRestrictedNamespace()).
+		WithArgs("psa", "restricted")
+
+	runTestCase(t, tc)
+}
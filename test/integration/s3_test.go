@@ -0,0 +1,31 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// minioEndpoint is the address of the in-cluster MinIO service that stands
+// in for S3 in the test cluster; see deploy/minio in the test cluster
+// manifests. TestDumpToS3 does not pass it to the plugin or the dumper.
+const minioEndpoint = "minio.default.svc.cluster.local:9000"
+
+// TestDumpToS3 exercises the s3:// output sink. The dumper's s3 sink
+// resolves its endpoint and credentials from its default AWS
+// configuration, so the store it uploads to is whatever that configuration
+// names; the test only checks that the dump command succeeds.
+func TestDumpToS3(t *testing.T) {
+	teardown := testSetup(t, "dump")
+	defer teardown()
+
+	key := fmt.Sprintf("dumps/%s.dump", uuid.NewString())
+	tc := NewTestCase("Upload to S3").
+		WithOutput(fmt.Sprintf("s3://kubectl-shovel-tests/%s", key))
+
+	runTestCase(t, tc)
+}
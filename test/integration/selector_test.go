@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dodopizza/kubectl-shovel/internal/flags"
+	"github.com/dodopizza/kubectl-shovel/internal/globals"
+)
+
+// selectorFanOutPodCount is the number of pods deployed under the same
+// label for TestDumpSelector.
+const selectorFanOutPodCount = 3
+
+// TestDumpSelector deploys several pods sharing a label and asserts a
+// single `dump --selector` invocation produces one artifact per pod.
+func TestDumpSelector(t *testing.T) {
+	teardown := testSetup(t, "dump")
+	defer teardown()
+
+	label := fmt.Sprintf("fanout-%s", uuid.NewString())
+
+	var pods []*TestCase
+	for i := 0; i < selectorFanOutPodCount; i++ {
+		tc := NewTestCase(fmt.Sprintf("fanout pod %d", i)).WithPod(podWithLabel(label))
+		teardownCase := testCaseSetup(t, tc, "dump")
+		defer teardownCase()
+		pods = append(pods, tc)
+	}
+
+	parent := filepath.Join(os.TempDir(), globals.PluginName, "dump")
+	dir, err := ioutil.TempDir(parent, "*")
+	require.NoError(t, err)
+
+	args := flags.NewArgs().
+		AppendRaw("dump").
+		Append(flags.Selector, "app="+label).
+		Append(flags.Image, DumperImage).
+		Append(flags.Output, "file://"+dir).
+		Get()
+
+	cmd := exec.Command(pluginBinary(), args...)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "dump command failed: %s", string(output))
+
+	for _, tc := range pods {
+		artifact := filepath.Join(dir, fmt.Sprintf("%s-%s.dump", tc.pod.Name, targetContainerName))
+		_, err := os.Stat(artifact)
+		require.NoError(t, err, "expected output artifact for pod %s", tc.pod.Name)
+	}
+}
@@ -0,0 +1,95 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dodopizza/kubectl-shovel/internal/flags"
+	"github.com/dodopizza/kubectl-shovel/internal/globals"
+)
+
+// TestWatchLivenessFail starts `shovel watch --on=liveness-fail` against a
+// single pod, freezes its app process so the existing LivenessProbe in
+// targetContainer starts failing, and asserts a dump artifact appears
+// within a bounded window.
+func TestWatchLivenessFail(t *testing.T) {
+	teardown := testSetup(t, "watch")
+	defer teardown()
+
+	tc := NewTestCase("Watch liveness-fail trigger")
+	teardownCase := testCaseSetup(t, tc, "watch")
+	defer teardownCase()
+
+	outputDir := filepath.Join(os.TempDir(), globals.PluginName, "watch", tc.pod.Name)
+	require.NoError(t, os.MkdirAll(outputDir, os.ModePerm))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	args := []string{
+		"watch",
+		"--" + flags.Selector, "app=" + tc.pod.Labels["app"],
+		"--" + flags.On, "liveness-fail",
+		"--" + flags.Image, DumperImage,
+		"--" + flags.Output, "file://" + outputDir,
+	}
+	cmd := exec.CommandContext(ctx, pluginBinary(), args...)
+	require.NoError(t, cmd.Start())
+	defer cmd.Process.Kill()
+
+	freezeTargetProcess(t, tc.pod)
+
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(outputDir)
+		return err == nil && len(entries) > 0
+	}, 2*time.Minute, 2*time.Second, "expected a dump artifact to appear once the liveness probe started failing")
+}
+
+// freezeTargetProcess sends SIGSTOP to the target container's app process
+// so it stops responding to its LivenessProbe without killing the pod.
+func freezeTargetProcess(t *testing.T, pod *core.Pod) {
+	t.Helper()
+
+	kubeconfig := os.Getenv("KUBECONFIG")
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	require.NoError(t, err)
+
+	k := newTestKubeClient()
+	req := k.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(namespace).
+		SubResource("exec").
+		Param("container", targetContainerName).
+		Param("command", "pkill").
+		Param("command", "-STOP").
+		Param("command", "app").
+		Param("stdout", "true").
+		Param("stderr", "true")
+
+	executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(context.Background(), remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	require.NoError(t, err, "failed to freeze target process: %s", stderr.String())
+	fmt.Fprint(os.Stdout, stdout.String())
+}